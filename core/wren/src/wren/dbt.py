@@ -3,12 +3,15 @@
 from __future__ import annotations
 
 import base64
+import ipaddress
 import json
 import os
 import re
+from collections.abc import Callable
 from dataclasses import dataclass
 from pathlib import Path
 from typing import Any
+from urllib.parse import parse_qsl, unquote, urlsplit
 
 import yaml
 
@@ -18,27 +21,53 @@ _DEFAULT_DBT_TARGET_PATH = "target"
 _COMPILED_DIR = "compiled"
 _DBT_PROJECT_FILE = "dbt_project.yml"
 _DBT_PROFILES_FILE = "profiles.yml"
+_DBT_TARGET_FILE = ".dbt_target"
+# Top-level profiles.yml key holding values for ``var()``; never a profile.
+_DBT_VARS_KEY = "vars"
 _MANIFEST_FILE = "manifest.json"
 _CATALOG_FILE = "catalog.json"
 _RUN_RESULTS_FILE = "run_results.json"
 
-_ENV_VAR_PATTERN = re.compile(
-    r"""
-    \{\{\s*
-    env_var
-    \(\s*
-    (?P<quote1>['"])
-    (?P<name>[^'"]+)
-    (?P=quote1)
-    (?:\s*,\s*
-        (?P<quote2>['"])
-        (?P<default>[^'"]*)
-        (?P=quote2)
-    )?
-    \s*\)
-    \s*\}\}
-    """,
-    re.VERBOSE,
+
+def _jinja_call_pattern(function: str) -> re.Pattern[str]:
+    """Match ``{{ function('name') }}`` with an optional quoted default."""
+    return re.compile(
+        rf"""
+        \{{\{{\s*
+        {re.escape(function)}
+        \(\s*
+        (?P<quote1>['"])
+        (?P<name>[^'"]+)
+        (?P=quote1)
+        (?:\s*,\s*
+            (?P<quote2>['"])
+            (?P<default>[^'"]*)
+            (?P=quote2)
+        )?
+        \s*\)
+        \s*\}}\}}
+        """,
+        re.VERBOSE,
+    )
+
+
+_ENV_VAR_PATTERN = _jinja_call_pattern("env_var")
+_VAR_PATTERN = _jinja_call_pattern("var")
+
+_POSTGRES_DSN_KEYS = ("dsn", "connection_string")
+_POSTGRES_DSN_FIELDS = ("host", "port", "dbname", "user", "password", "sslmode")
+_POSTGRES_TARGET_SESSION_ATTRS = (
+    "any",
+    "read-write",
+    "read-only",
+    "primary",
+    "standby",
+    "prefer-standby",
+)
+_POSTGRES_REPLICATION_MODES = ("true", "database")
+_POSTGRES_TIMEOUT_SETTINGS = (
+    "statement_timeout",
+    "idle_in_transaction_session_timeout",
 )
 
 DBT_ADAPTER_TO_WREN_DATASOURCE = {
@@ -48,13 +77,37 @@ DBT_ADAPTER_TO_WREN_DATASOURCE = {
     "databricks": "databricks",
     "doris": "mysql",
     "duckdb": "duckdb",
+    "memsql": "mysql",
     "mysql": "mysql",
     "postgres": "postgres",
     "redshift": "redshift",
+    "singlestore": "mysql",
     "snowflake": "snowflake",
     "spark": "spark",
     "sqlserver": "mssql",
+    "starrocks": "mysql",
     "trino": "trino",
+    "yugabytedb": "postgres",
+}
+
+# Field sets that identify an adapter when a dbt output omits ``type``.
+_DBT_ADAPTER_SIGNATURE_FIELDS = {
+    "postgres": ("host", "port", "dbname"),
+    "snowflake": ("account", "warehouse"),
+}
+
+# Generic dbt output settings that say nothing about where to connect; an
+# output with only these (e.g. ``type`` and ``threads``) cannot be converted.
+_DBT_NON_CONNECTION_FIELDS = frozenset({"type", "threads", "schema", "retries"})
+
+_STARROCKS_DEFAULT_CATALOG = "default_catalog"
+
+# Wire-compatible adapters whose servers listen on a non-standard default port.
+_DBT_ADAPTER_DEFAULT_PORTS = {
+    "memsql": "3306",
+    "singlestore": "3306",
+    "starrocks": "9030",
+    "yugabytedb": "5433",
 }
 
 
@@ -118,6 +171,37 @@ def map_dbt_adapter_to_wren(adapter_type: str) -> str:
         ) from exc
 
 
+def list_unsupported_dbt_adapters(
+    profiles: dict[str, dict[str, Any]],
+) -> dict[str, int]:
+    """Count outputs per dbt adapter that has no Wren datasource mapping.
+
+    An output without ``type`` falls back to its profile's ``type``. Outputs
+    with neither, or whose type is still an unresolved ``{{ ... }}`` template,
+    are ignored.
+    """
+    counts: dict[str, int] = {}
+    for profile_name, profile in profiles.items():
+        if profile_name == _DBT_VARS_KEY:
+            continue
+        outputs = profile.get("outputs") if isinstance(profile, dict) else None
+        if not isinstance(outputs, dict):
+            continue
+        for output in outputs.values():
+            if not isinstance(output, dict):
+                continue
+            declared_type = output.get("type") or profile.get("type")
+            if not declared_type:
+                continue
+            raw_type = str(declared_type)
+            if "{{" in raw_type:
+                continue
+            adapter_type = raw_type.strip().lower()
+            if adapter_type not in DBT_ADAPTER_TO_WREN_DATASOURCE:
+                counts[adapter_type] = counts.get(adapter_type, 0) + 1
+    return dict(sorted(counts.items()))
+
+
 def resolve_env_vars(value: Any, env: dict[str, str] | None = None) -> Any:
     """Recursively resolve dbt ``env_var()`` references inside YAML values."""
     env_map = env if env is not None else os.environ
@@ -143,6 +227,42 @@ def resolve_env_vars(value: Any, env: dict[str, str] | None = None) -> Any:
     return _ENV_VAR_PATTERN.sub(_replace, value)
 
 
+def resolve_dbt_vars(value: Any, variables: dict[str, Any]) -> Any:
+    """Recursively resolve dbt ``var()`` references inside YAML values."""
+    if isinstance(value, dict):
+        return {k: resolve_dbt_vars(v, variables) for k, v in value.items()}
+    if isinstance(value, list):
+        return [resolve_dbt_vars(v, variables) for v in value]
+    if not isinstance(value, str):
+        return value
+
+    def _replace(match: re.Match[str]) -> str:
+        name = match.group("name")
+        default = match.group("default")
+        if name in variables:
+            return str(variables[name])
+        if default is not None:
+            return default
+        raise DbtLoadError(
+            f"dbt var '{name}' is required by dbt config but is not defined."
+        )
+
+    return _VAR_PATTERN.sub(_replace, value)
+
+
+def parse_dbt_vars(raw: str | None) -> dict[str, Any] | None:
+    """Parse a dbt-style ``--vars`` YAML mapping string."""
+    if raw is None or not raw.strip():
+        return None
+    try:
+        parsed = yaml.safe_load(raw)
+    except yaml.YAMLError as exc:
+        raise DbtLoadError(f"--vars is not valid YAML: {exc}") from exc
+    if not isinstance(parsed, dict):
+        raise DbtLoadError("--vars must be a YAML mapping, e.g. '{db_host: db}'.")
+    return parsed
+
+
 def load_dbt_project(project_dir: str | Path) -> dict[str, Any]:
     """Load ``dbt_project.yml`` from a dbt project directory."""
     root = Path(project_dir).expanduser().resolve()
@@ -175,7 +295,9 @@ def load_dbt_profiles(
     else:
         path = Path.home() / ".dbt" / _DBT_PROFILES_FILE
 
-    profiles = _load_yaml_file(path, label="dbt profiles")
+    profiles = _load_yaml_file(
+        path, label="dbt profiles", check=_check_duplicate_profile_keys
+    )
     if profiles is None:
         raise DbtLoadError(f"dbt profiles file is empty: {path}")
     if not isinstance(profiles, dict):
@@ -190,8 +312,13 @@ def resolve_dbt_target(
     profile_name: str | None = None,
     target_name: str | None = None,
     env: dict[str, str] | None = None,
+    dbt_vars: dict[str, Any] | None = None,
 ) -> DbtTarget:
-    """Resolve the active dbt profile and target for a project."""
+    """Resolve the active dbt profile and target for a project.
+
+    ``var()`` references resolve against the top-level ``vars`` mapping in
+    profiles.yml, overridden by *dbt_vars*.
+    """
     root = Path(project_dir).expanduser().resolve()
     project = load_dbt_project(root)
     selected_profile_name = profile_name or project.get("profile")
@@ -201,8 +328,9 @@ def resolve_dbt_target(
         )
 
     profiles = load_dbt_profiles(profiles_path)
-    if selected_profile_name not in profiles:
-        available = ", ".join(sorted(profiles)) or "none"
+    profile_names = sorted(name for name in profiles if name != _DBT_VARS_KEY)
+    if selected_profile_name not in profile_names:
+        available = ", ".join(profile_names) or "none"
         raise DbtLoadError(
             f"dbt profile '{selected_profile_name}' not found in profiles.yml. "
             f"Available profiles: {available}."
@@ -218,7 +346,11 @@ def resolve_dbt_target(
             f"dbt profile '{selected_profile_name}' is missing 'outputs'."
         )
 
-    selected_target_name = target_name or profile.get("target")
+    selected_target_name = (
+        target_name or profile.get("target") or _read_pinned_target(root)
+    )
+    if not selected_target_name and len(outputs) == 1:
+        selected_target_name = next(iter(outputs))
     if not selected_target_name:
         raise DbtLoadError(
             f"dbt profile '{selected_profile_name}' is missing 'target'. "
@@ -231,16 +363,38 @@ def resolve_dbt_target(
             f"'{selected_profile_name}'. Available targets: {available_targets}."
         )
 
-    resolved_output = resolve_env_vars(outputs[selected_target_name], env=env)
+    embedded_vars = profiles.get(_DBT_VARS_KEY)
+    variables = {
+        **(embedded_vars if isinstance(embedded_vars, dict) else {}),
+        **(dbt_vars or {}),
+    }
+    raw_output = outputs[selected_target_name]
+    if (
+        isinstance(raw_output, dict)
+        and not raw_output.get("type")
+        and profile.get("type")
+    ):
+        raw_output = {**raw_output, "type": profile["type"]}
+    deferred_password = None
+    if isinstance(raw_output, dict) and _defers_to_password_file(raw_output):
+        deferred_password = raw_output["password"]
+        raw_output = {k: v for k, v in raw_output.items() if k != "password"}
+    resolved_output = resolve_env_vars(resolve_dbt_vars(raw_output, variables), env=env)
     if not isinstance(resolved_output, dict):
         raise DbtLoadError(
             f"dbt target '{selected_target_name}' must resolve to a mapping."
         )
+    if deferred_password is not None and not resolved_output.get("password_file"):
+        resolved_output = {
+            **resolved_output,
+            "password": resolve_env_vars(
+                resolve_dbt_vars(deferred_password, variables), env=env
+            ),
+        }
+    resolved_output = _resolve_password_file(resolved_output, root)
     adapter_type = str(resolved_output.get("type") or "").strip()
     if not adapter_type:
-        raise DbtLoadError(
-            f"dbt target '{selected_target_name}' is missing adapter 'type'."
-        )
+        adapter_type = _infer_dbt_adapter_type(resolved_output, selected_target_name)
 
     target_dir_name = str(project.get("target-path") or _DEFAULT_DBT_TARGET_PATH)
     target_path = root / target_dir_name
@@ -455,8 +609,20 @@ def convert_dbt_target_to_wren_profile(target: DbtTarget) -> dict[str, Any]:
     output = target.output
     datasource = target.datasource
 
+    set_fields = sorted(k for k, v in output.items() if v not in (None, ""))
+    if set(set_fields) <= _DBT_NON_CONNECTION_FIELDS:
+        raise DbtLoadError(
+            f"dbt target '{target.target_name}' has no connection details; "
+            f"it only sets: {', '.join(set_fields) or 'nothing'}."
+        )
+
     if datasource == "duckdb":
         path_value = str(_require_output_field(output, "path"))
+        if "://" in path_value:
+            raise DbtLoadError(
+                f"dbt duckdb target path '{path_value}' is a remote URL; Wren can "
+                "only import duckdb targets backed by local database files."
+            )
         db_path = Path(path_value).expanduser()
         if not db_path.is_absolute():
             db_path = (target.project_dir / db_path).resolve()
@@ -464,27 +630,36 @@ def convert_dbt_target_to_wren_profile(target: DbtTarget) -> dict[str, Any]:
         return _build_wren_profile("duckdb", {"url": str(url), "format": "duckdb"})
 
     if datasource == "postgres":
+        output = _apply_postgres_dsn(output)
         return _build_wren_profile(
             "postgres",
             {
                 "host": str(_require_output_field(output, "host")),
-                "port": str(output.get("port", "5432")),
+                "port": str(output.get("port") or _default_port(target, "5432")),
                 "database": str(_require_output_field(output, "dbname", "database")),
                 "user": str(_require_output_field(output, "user")),
                 "password": str(output["password"]) if output.get("password") else None,
+                "kwargs": _postgres_connect_kwargs(output),
             },
         )
 
     if datasource in {"mysql", "redshift", "mssql", "clickhouse"}:
+        port = output.get("port") or _default_port(target, None)
+        user_keys: tuple[str, ...] = ("user",)
+        database_keys: tuple[str, ...] = ("dbname", "database", "catalog")
+        if target.adapter_type.lower() == "starrocks":
+            # dbt-starrocks names the MySQL database ``schema``; ``catalog`` is a
+            # StarRocks catalog, which the mysql datasource cannot select.
+            _require_default_starrocks_catalog(output)
+            user_keys = ("username", "user")
+            database_keys = ("schema", "database")
         return _build_wren_profile(
             datasource,
             {
                 "host": str(_require_output_field(output, "host")),
-                "port": str(_require_output_field(output, "port")),
-                "database": str(
-                    _require_output_field(output, "dbname", "database", "catalog")
-                ),
-                "user": str(_require_output_field(output, "user")),
+                "port": str(port or _require_output_field(output, "port")),
+                "database": str(_require_output_field(output, *database_keys)),
+                "user": str(_require_output_field(output, *user_keys)),
                 "password": str(output["password"]) if output.get("password") else None,
             },
         )
@@ -507,7 +682,7 @@ def convert_dbt_target_to_wren_profile(target: DbtTarget) -> dict[str, Any]:
             "trino",
             {
                 "host": str(_require_output_field(output, "host")),
-                "port": str(output.get("port", "8080")),
+                "port": str(output.get("port") or "8080"),
                 "catalog": str(_require_output_field(output, "database", "catalog")),
                 "schema": str(_require_output_field(output, "schema")),
                 "user": output.get("user"),
@@ -539,7 +714,7 @@ def convert_dbt_target_to_wren_profile(target: DbtTarget) -> dict[str, Any]:
             "spark",
             {
                 "host": str(_require_output_field(output, "host")),
-                "port": str(output.get("port", "15002")),
+                "port": str(output.get("port") or "15002"),
             },
         )
 
@@ -579,6 +754,21 @@ def convert_dbt_target_to_wren_profile(target: DbtTarget) -> dict[str, Any]:
     )
 
 
+def _require_default_starrocks_catalog(output: dict[str, Any]) -> None:
+    """Reject StarRocks targets that point at an external catalog."""
+    catalog = output.get("catalog")
+    if catalog not in (None, "", _STARROCKS_DEFAULT_CATALOG):
+        raise DbtLoadError(
+            f"dbt starrocks target uses catalog '{catalog}', but Wren can only "
+            f"import targets on the '{_STARROCKS_DEFAULT_CATALOG}'."
+        )
+
+
+def _default_port(target: DbtTarget, fallback: str | None) -> str | None:
+    """Return the default port for the target's dbt adapter."""
+    return _DBT_ADAPTER_DEFAULT_PORTS.get(target.adapter_type.lower(), fallback)
+
+
 def _build_wren_profile(datasource: str, payload: dict[str, Any]) -> dict[str, Any]:
     """Validate a profile payload with Wren's connection-info model."""
     from pydantic import ValidationError  # noqa: PLC0415
@@ -614,16 +804,71 @@ def _serialize_profile_value(value: Any) -> Any:
     return value
 
 
-def _load_yaml_file(path: Path, *, label: str) -> Any:
-    """Load YAML from *path* with a consistent error surface."""
+def _load_yaml_file(
+    path: Path,
+    *,
+    label: str,
+    check: Callable[[yaml.Node, Path], None] | None = None,
+) -> Any:
+    """Load YAML from *path* with a consistent error surface.
+
+    *check*, when given, inspects the composed node tree before it is
+    constructed, e.g. to reject keys that plain loading would collapse.
+    """
     if not path.exists():
         raise DbtLoadError(f"{label} file not found: {path}")
     try:
-        return yaml.safe_load(path.read_text(encoding="utf-8"))
+        loader = yaml.SafeLoader(path.read_text(encoding="utf-8"))
+        try:
+            node = loader.get_single_node()
+            if node is None:
+                return None
+            if check is not None:
+                check(node, path)
+            return loader.construct_document(node)
+        finally:
+            loader.dispose()
     except yaml.YAMLError as exc:
         raise DbtLoadError(f"{label} is not valid YAML: {path}: {exc}") from exc
 
 
+def _check_duplicate_profile_keys(node: yaml.Node, path: Path) -> None:
+    """Reject profiles or outputs that YAML would silently overwrite."""
+    if not isinstance(node, yaml.MappingNode):
+        return
+    duplicate_profile = _first_duplicate_key(node)
+    if duplicate_profile is not None:
+        raise DbtLoadError(
+            f"dbt profile '{duplicate_profile}' is defined more than once in {path}."
+        )
+    for profile_key, profile_node in node.value:
+        if not isinstance(profile_node, yaml.MappingNode):
+            continue
+        for key_node, value_node in profile_node.value:
+            if key_node.value != "outputs" or not isinstance(
+                value_node, yaml.MappingNode
+            ):
+                continue
+            duplicate_output = _first_duplicate_key(value_node)
+            if duplicate_output is not None:
+                raise DbtLoadError(
+                    f"dbt profile '{profile_key.value}' defines output "
+                    f"'{duplicate_output}' more than once in {path}."
+                )
+
+
+def _first_duplicate_key(node: yaml.MappingNode) -> str | None:
+    """Return the first scalar key that repeats in a mapping node."""
+    seen: set[str] = set()
+    for key_node, _value_node in node.value:
+        if not isinstance(key_node, yaml.ScalarNode):
+            continue
+        if key_node.value in seen:
+            return key_node.value
+        seen.add(key_node.value)
+    return None
+
+
 def _resolve_profiles_file_path(path: str | Path, *, assume_dir: bool = False) -> Path:
     """Resolve a dbt profiles path or directory to a profiles.yml file."""
     resolved = Path(path).expanduser()
@@ -648,6 +893,69 @@ def _load_json_file(path: Path, *, label: str) -> dict[str, Any]:
     return data
 
 
+def _read_pinned_target(root: Path) -> str | None:
+    """Return the target pinned in ``<project>/.dbt_target``, if any."""
+    target_file = root / _DBT_TARGET_FILE
+    if not target_file.is_file():
+        return None
+    return target_file.read_text(encoding="utf-8").strip() or None
+
+
+def _infer_dbt_adapter_type(output: dict[str, Any], target_name: str) -> str:
+    """Guess the adapter of an output without ``type`` from its fields."""
+    candidates = [
+        adapter
+        for adapter, fields in _DBT_ADAPTER_SIGNATURE_FIELDS.items()
+        if all(output.get(field) not in (None, "") for field in fields)
+    ]
+    if not candidates:
+        raise DbtLoadError(f"dbt target '{target_name}' is missing adapter 'type'.")
+    if len(candidates) > 1:
+        raise DbtLoadError(
+            f"dbt target '{target_name}' is missing adapter 'type' and its fields "
+            f"match several adapters: {', '.join(candidates)}. Set 'type' explicitly."
+        )
+    return candidates[0]
+
+
+def _defers_to_password_file(output: dict[str, Any]) -> bool:
+    """Return whether a templated password may yield to ``password_file``.
+
+    Password precedence is a literal ``password``, then ``password_file``, then
+    a ``password`` taken from ``env_var()`` or ``var()``. The caller only drops
+    the password once ``password_file`` resolves to a non-empty path.
+    """
+    password = output.get("password")
+    return bool(
+        output.get("password_file")
+        and isinstance(password, str)
+        and (_ENV_VAR_PATTERN.search(password) or _VAR_PATTERN.search(password))
+    )
+
+
+def _resolve_password_file(output: dict[str, Any], root: Path) -> dict[str, Any]:
+    """Read ``password_file`` into ``password`` unless a password is already set.
+
+    Relative paths are resolved against the dbt project directory.
+    """
+    if output.get("password") not in (None, "") or not output.get("password_file"):
+        return output
+
+    password_path = Path(str(output["password_file"])).expanduser()
+    if not password_path.is_absolute():
+        password_path = root / password_path
+    try:
+        password = password_path.read_text(encoding="utf-8").strip()
+    except OSError as exc:
+        raise DbtLoadError(
+            f"dbt target password_file could not be read: {password_path}: "
+            f"{exc.strerror or exc}"
+        ) from exc
+    if not password:
+        raise DbtLoadError(f"dbt target password_file is empty: {password_path}")
+    return {**output, "password": password}
+
+
 def _require_output_field(output: dict[str, Any], *keys: str) -> Any:
     """Return the first present non-empty output field."""
     for key in keys:
@@ -663,6 +971,158 @@ def _filter_none(values: dict[str, Any]) -> dict[str, Any]:
     return {key: value for key, value in values.items() if value is not None}
 
 
+def _apply_postgres_dsn(output: dict[str, Any]) -> dict[str, Any]:
+    """Fill discrete postgres fields from a ``dsn`` or ``connection_string``.
+
+    Fields set directly on the dbt output override the DSN-derived values.
+    """
+    dsn = next((output[key] for key in _POSTGRES_DSN_KEYS if output.get(key)), None)
+    if dsn is None:
+        return output
+
+    parsed = _parse_postgres_dsn(str(dsn))
+    if output.get("database") not in (None, ""):
+        parsed.pop("dbname", None)
+    merged = {k: v for k, v in parsed.items() if v not in (None, "")}
+    merged.update({k: v for k, v in output.items() if v not in (None, "")})
+    return merged
+
+
+def _parse_postgres_dsn(dsn: str) -> dict[str, Any]:
+    """Parse a libpq URI or ``key=value`` DSN into dbt-style postgres fields."""
+    if "://" in dsn:
+        parts = urlsplit(dsn)
+        if parts.scheme not in {"postgres", "postgresql"}:
+            raise DbtLoadError(
+                f"Malformed postgres dsn: unsupported scheme '{parts.scheme}'."
+            )
+        if "," in parts.netloc:
+            raise DbtLoadError(
+                "Malformed postgres dsn: multiple hosts are not supported."
+            )
+        try:
+            port = parts.port
+        except ValueError as exc:
+            raise DbtLoadError("Malformed postgres dsn: invalid port.") from exc
+        values: dict[str, Any] = {
+            "host": parts.hostname,
+            "port": port,
+            "dbname": unquote(parts.path.lstrip("/")),
+            "user": unquote(parts.username) if parts.username else None,
+            "password": unquote(parts.password) if parts.password else None,
+        }
+        values.update(parse_qsl(parts.query))
+    else:
+        values = _parse_postgres_conninfo(dsn)
+    return {key: values[key] for key in _POSTGRES_DSN_FIELDS if key in values}
+
+
+def _parse_postgres_conninfo(dsn: str) -> dict[str, str]:
+    """Tokenize a libpq ``key = value`` conninfo string.
+
+    Follows libpq: whitespace around ``=`` is allowed, values may be wrapped in
+    single quotes, and a backslash escapes the next character.
+    """
+    values: dict[str, str] = {}
+    pos, length = 0, len(dsn)
+    while True:
+        while pos < length and dsn[pos].isspace():
+            pos += 1
+        if pos == length:
+            return values
+        start = pos
+        while pos < length and dsn[pos] != "=" and not dsn[pos].isspace():
+            pos += 1
+        key = dsn[start:pos]
+        while pos < length and dsn[pos].isspace():
+            pos += 1
+        if not key or pos == length or dsn[pos] != "=":
+            raise DbtLoadError(
+                "Malformed postgres dsn: expected space-separated key=value pairs."
+            )
+        pos += 1
+        while pos < length and dsn[pos].isspace():
+            pos += 1
+        quoted = pos < length and dsn[pos] == "'"
+        if quoted:
+            pos += 1
+        chars: list[str] = []
+        while pos < length:
+            char = dsn[pos]
+            if quoted and char == "'":
+                break
+            if not quoted and char.isspace():
+                break
+            if char == "\\" and pos + 1 < length:
+                pos += 1
+                char = dsn[pos]
+            chars.append(char)
+            pos += 1
+        if quoted:
+            if pos == length:
+                raise DbtLoadError(
+                    f"Malformed postgres dsn: unterminated quoted value for '{key}'."
+                )
+            pos += 1
+        values[key] = "".join(chars)
+
+
+def _postgres_connect_kwargs(output: dict[str, Any]) -> dict[str, str] | None:
+    """Collect libpq connection parameters that have no dedicated Wren field."""
+    kwargs: dict[str, str] = {}
+    if output.get("sslmode"):
+        kwargs["sslmode"] = str(output["sslmode"])
+    if output.get("hostaddr"):
+        hostaddr = str(output["hostaddr"])
+        try:
+            ipaddress.ip_address(hostaddr)
+        except ValueError as exc:
+            raise DbtLoadError(
+                f"Invalid postgres hostaddr '{hostaddr}': expected an IP address."
+            ) from exc
+        kwargs["hostaddr"] = hostaddr
+    if output.get("target_session_attrs"):
+        session_attrs = str(output["target_session_attrs"])
+        if session_attrs not in _POSTGRES_TARGET_SESSION_ATTRS:
+            raise DbtLoadError(
+                f"Invalid postgres target_session_attrs '{session_attrs}'. "
+                f"Expected one of: {', '.join(_POSTGRES_TARGET_SESSION_ATTRS)}."
+            )
+        kwargs["target_session_attrs"] = session_attrs
+    replication = output.get("replication")
+    if replication not in (None, "", False):
+        replication_mode = str(replication).lower()
+        if replication_mode not in _POSTGRES_REPLICATION_MODES:
+            raise DbtLoadError(
+                f"Invalid postgres replication mode '{replication}'. "
+                f"Expected one of: {', '.join(_POSTGRES_REPLICATION_MODES)}."
+            )
+        kwargs["replication"] = replication_mode
+
+    options = [str(output["options"])] if output.get("options") else []
+    for setting in _POSTGRES_TIMEOUT_SETTINGS:
+        if output.get(setting) is None:
+            continue
+        timeout_ms = _non_negative_int(output[setting], field=setting)
+        options.append(f"-c {setting}={timeout_ms}")
+    if options:
+        kwargs["options"] = " ".join(options)
+    return kwargs or None
+
+
+def _non_negative_int(value: Any, *, field: str) -> int:
+    """Coerce a dbt output value to a non-negative integer."""
+    try:
+        number = int(value)
+    except (TypeError, ValueError) as exc:
+        raise DbtLoadError(
+            f"dbt target field '{field}' must be an integer, got {value!r}."
+        ) from exc
+    if number < 0:
+        raise DbtLoadError(f"dbt target field '{field}' must not be negative.")
+    return number
+
+
 def _bigquery_credentials_base64(output: dict[str, Any]) -> str:
     """Encode BigQuery credentials from dbt output into Wren's expected format."""
     if output.get("credentials"):
@@ -192,6 +192,13 @@ def import_cmd(
         Optional[str],
         typer.Option("--target", help="dbt target name override."),
     ] = None,
+    dbt_vars: Annotated[
+        Optional[str],
+        typer.Option(
+            "--vars",
+            help="YAML mapping of values for var() references, as in dbt --vars.",
+        ),
+    ] = None,
     name: Annotated[
         Optional[str],
         typer.Option("--name", help="Destination Wren profile name."),
@@ -213,6 +220,7 @@ def import_cmd(
         DbtLoadError,
         convert_dbt_target_to_wren_profile,
         default_wren_profile_name,
+        parse_dbt_vars,
         resolve_dbt_target,
     )
     from wren.model.data_source import DataSource  # noqa: PLC0415
@@ -224,6 +232,7 @@ def import_cmd(
             profiles_path=profiles_path,
             profile_name=profile_name,
             target_name=target_name,
+            dbt_vars=parse_dbt_vars(dbt_vars),
         )
         profile_data = convert_dbt_target_to_wren_profile(dbt_target)
         datasource = DataSource(profile_data["datasource"])
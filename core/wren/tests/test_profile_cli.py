@@ -307,6 +307,38 @@ def test_import_dbt_postgres_profile_custom_name_no_activate(tmp_path):
     assert profile_mod.get_active_name() == "existing"
 
 
+def test_import_dbt_postgres_profile_with_vars(tmp_path):
+    project_dir, profiles_path = _write_dbt_project(tmp_path)
+    profiles_path.write_text(
+        "jaffle_shop:\n"
+        "  target: dev\n"
+        "  outputs:\n"
+        "    dev:\n"
+        "      type: postgres\n"
+        "      host: \"{{ var('db_host') }}\"\n"
+        "      port: 5432\n"
+        "      dbname: analytics\n"
+        "      user: postgres\n"
+    )
+
+    result = runner.invoke(
+        profile_app,
+        [
+            "import",
+            "dbt",
+            "--project-dir",
+            str(project_dir),
+            "--profiles-path",
+            str(profiles_path),
+            "--vars",
+            "{db_host: db.internal}",
+        ],
+    )
+
+    assert result.exit_code == 0, result.output
+    assert profile_mod.list_profiles()["jaffle-shop-dev"]["host"] == "db.internal"
+
+
 def test_import_dbt_unsupported_source(tmp_path):
     project_dir, profiles_path = _write_dbt_project(tmp_path)
     profiles_path.write_text("{}\n")